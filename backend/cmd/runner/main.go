@@ -0,0 +1,255 @@
+// cmd/runner/main.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"strings"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"sd-leetcode/backend/internal/judger"
+	"sd-leetcode/backend/internal/models"
+	"sd-leetcode/backend/internal/queue"
+	"sd-leetcode/backend/internal/storage"
+)
+
+// ----------
+// Global variables
+// ----------
+
+var (
+	db    *gorm.DB
+	store *storage.Store
+)
+
+// ----------
+// Main
+// ----------
+
+func main() {
+	// Connect to PostgreSQL. Runners only need read/write access to
+	// submissions and problems/test cases, never to the API server's
+	// in-memory state.
+	dsn := "host=localhost user=postgres password=123456 dbname=postgres port=5432 sslmode=disable TimeZone=UTC"
+	var err error
+	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	j, err := judger.New()
+	if err != nil {
+		log.Fatalf("failed to connect to docker: %v", err)
+	}
+	defer j.Close()
+
+	store, err = storage.New(context.Background(), "localhost:9000", "minioadmin", "minioadmin", "sd-leetcode", false)
+	if err != nil {
+		log.Fatalf("failed to connect to object storage: %v", err)
+	}
+
+	redisAddr := "localhost:6379"
+	srv := queue.NewServer(redisAddr, 10)
+	mux := queue.Mux(&submissionHandler{judger: j})
+
+	// srv.Run blocks and, on SIGINT/SIGTERM, stops pulling new jobs and
+	// waits for in-flight jobs to finish before returning.
+	if err := srv.Run(mux); err != nil {
+		log.Fatalf("runner server failed: %v", err)
+	}
+}
+
+// submissionHandler executes submission jobs inside Docker containers and
+// writes the verdict back to Postgres.
+type submissionHandler struct {
+	judger *judger.Judger
+}
+
+// HandleSubmission implements queue.Handler.
+func (h *submissionHandler) HandleSubmission(ctx context.Context, job queue.SubmissionJob) error {
+	var sub models.Submission
+	if err := db.First(&sub, job.SubmissionID).Error; err != nil {
+		return err
+	}
+
+	if _, ok := judger.ConfigFor(sub.Language); !ok {
+		sub.Status = "completed"
+		sub.Verdict = string(judger.RE)
+		sub.Output = "Unsupported language: " + sub.Language
+		return db.Save(&sub).Error
+	}
+
+	// Load the problem version the submission was judged against; its
+	// test cases are an immutable snapshot, so editing the live problem
+	// never changes the outcome of an already-judged submission.
+	var version models.ProblemVersion
+	if err := db.First(&version, sub.ProblemVersionID).Error; err != nil {
+		sub.Status = "completed"
+		sub.Verdict = string(judger.RE)
+		sub.Output = "Error loading problem version"
+		db.Save(&sub)
+		return nil
+	}
+	if !version.IsEnabled {
+		sub.Status = "completed"
+		sub.Verdict = string(judger.RE)
+		sub.Output = "Problem version has been disabled"
+		db.Save(&sub)
+		return nil
+	}
+
+	var testCases []models.VersionedTestCase
+	if err := json.Unmarshal(version.TestCases, &testCases); err != nil {
+		sub.Status = "completed"
+		sub.Verdict = string(judger.RE)
+		sub.Output = "Error reading test cases"
+		db.Save(&sub)
+		return nil
+	}
+
+	// Use the function name stored on the version.
+	functionName := version.FunctionName
+	if functionName == "" {
+		functionName = "solve" // default fallback if not set
+	}
+
+	results := make([]models.TestCaseResult, 0, len(testCases))
+	overall := judger.AC
+
+	for i, tc := range testCases {
+		inputBytes := []byte(tc.Input)
+		if tc.InputKey != "" {
+			fetched, err := readObject(ctx, tc.InputKey)
+			if err != nil {
+				return err
+			}
+			inputBytes = fetched
+		}
+
+		// Every language gets the test case input as raw JSON on stdin:
+		// runner.py and runner.js parse it into typed arguments, and
+		// go/cpp/java submissions read it themselves.
+		input := string(inputBytes)
+
+		result, err := h.judger.Run(ctx, sub.Language, sub.Code, functionName, input)
+		if err != nil {
+			result.Verdict = judger.RE
+			result.Output = err.Error()
+		} else if result.Verdict == judger.AC {
+			output := strings.ReplaceAll(strings.TrimSpace(result.Output), "\x00", "")
+			matched, err := testCaseMatches(ctx, sub.Language, output, tc)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				result.Verdict = judger.WA
+			}
+			result.Output = output
+		}
+
+		if result.Verdict != judger.AC && overall == judger.AC {
+			overall = result.Verdict
+		}
+
+		results = append(results, models.TestCaseResult{
+			Index:    i,
+			Verdict:  string(result.Verdict),
+			Output:   result.Output,
+			TimeMs:   result.TimeMs,
+			MemoryKB: result.MemoryKB,
+		})
+	}
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	sub.Verdict = string(overall)
+	sub.Passed = overall == judger.AC
+	sub.Results = resultsJSON
+	if sub.Passed {
+		sub.Output = "All test cases passed."
+	} else {
+		sub.Output = string(overall)
+	}
+	sub.Status = "completed"
+	return db.Save(&sub).Error
+}
+
+// testCaseMatches compares a container's stdout against a test case's
+// expected output. Outputs stored in object storage (tc.OutputKey) are
+// streamed and compared byte-for-byte, which is how outputs larger than
+// the inline JSON threshold are supported. Inline outputs of python and
+// javascript, whose harnesses print JSON, are compared after parsing both
+// sides as JSON, since runner.py may format whitespace differently than
+// Go's json.Marshal does; other languages' output is compared with the
+// expected value's flattened string form (see jsonToPlainString).
+func testCaseMatches(ctx context.Context, language, output string, tc models.VersionedTestCase) (bool, error) {
+	if tc.OutputKey != "" {
+		expected, err := store.Get(ctx, tc.OutputKey)
+		if err != nil {
+			return false, err
+		}
+		defer expected.Close()
+		expectedBytes, err := io.ReadAll(expected)
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal([]byte(output), bytes.TrimSpace(expectedBytes)), nil
+	}
+
+	if language != "python" && language != "javascript" {
+		return output == jsonToPlainString(tc.Output), nil
+	}
+
+	var got, want interface{}
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(tc.Output, &want); err != nil {
+		return false, nil
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		return false, nil
+	}
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		return false, nil
+	}
+	return string(gotJSON) == string(wantJSON), nil
+}
+
+// readObject fetches and fully reads an object-storage key.
+func readObject(ctx context.Context, key string) ([]byte, error) {
+	obj, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	return io.ReadAll(obj)
+}
+
+// jsonToPlainString flattens a datatypes.JSON value into the plain string
+// form that go/cpp/java output is compared against: strings pass through
+// unquoted, everything else round-trips through json.Marshal.
+func jsonToPlainString(raw []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(bs)
+}
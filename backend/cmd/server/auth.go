@@ -0,0 +1,117 @@
+// cmd/server/auth.go
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"sd-leetcode/backend/internal/auth"
+	"sd-leetcode/backend/internal/models"
+)
+
+const tokenTTL = 24 * time.Hour
+
+// defaultAdminEmail is the bootstrap admin account's login. It isn't
+// sensitive, so unlike the password it's fine to default rather than
+// require from the environment.
+const defaultAdminEmail = "admin@sd-leetcode.local"
+
+// seedAdmin creates the bootstrap admin account if no admin exists yet.
+// Without it, every account apiRegister creates has RoleUser and the
+// admin surface (problem/version create, rejudge, test-case upload) has
+// no way to be exercised. The password comes from ADMIN_BOOTSTRAP_PASSWORD
+// rather than a hardcoded default, since a literal in source is as good
+// as public.
+func seedAdmin() {
+	var count int64
+	db.Model(&models.User{}).Where("role = ?", auth.RoleAdmin).Count(&count)
+	if count > 0 {
+		return
+	}
+
+	password := requireEnv("ADMIN_BOOTSTRAP_PASSWORD")
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Fatalf("failed to hash default admin password: %v", err)
+	}
+	admin := models.User{
+		Email:        defaultAdminEmail,
+		PasswordHash: string(hash),
+		Role:         auth.RoleAdmin,
+		CreatedAt:    time.Now(),
+	}
+	if err := db.Create(&admin).Error; err != nil {
+		log.Fatalf("failed to seed default admin: %v", err)
+	}
+	log.Printf("seeded default admin account %s - sign in with the password in ADMIN_BOOTSTRAP_PASSWORD", defaultAdminEmail)
+}
+
+// apiRegister creates a new account with the "user" role and returns a
+// signed JWT, same as apiLogin would for that account.
+func apiRegister(c *gin.Context) {
+	var payload struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil || payload.Email == "" || payload.Password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "email and password are required"})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not hash password"})
+		return
+	}
+
+	user := models.User{
+		Email:        payload.Email,
+		PasswordHash: string(hash),
+		Role:         auth.RoleUser,
+		CreatedAt:    time.Now(),
+	}
+	if err := db.Create(&user).Error; err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Email already registered"})
+		return
+	}
+
+	token, err := auth.GenerateToken(jwtSecret, user.ID, user.Role, tokenTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not generate token"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token, "user": user})
+}
+
+// apiLogin verifies credentials and returns a signed JWT.
+func apiLogin(c *gin.Context) {
+	var payload struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	var user models.User
+	if err := db.Where("email = ?", payload.Email).First(&user).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(payload.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	}
+
+	token, err := auth.GenerateToken(jwtSecret, user.ID, user.Role, tokenTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not generate token"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token, "user": user})
+}
@@ -0,0 +1,623 @@
+// cmd/server/main.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"gorm.io/datatypes"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"sd-leetcode/backend/internal/auth"
+	"sd-leetcode/backend/internal/models"
+	"sd-leetcode/backend/internal/queue"
+	"sd-leetcode/backend/internal/storage"
+)
+
+// ----------
+// Global variables
+// ----------
+
+var (
+	db          *gorm.DB
+	queueClient *asynq.Client
+	objectStore *storage.Store
+	jwtSecret   []byte // set from the JWT_SECRET env var in main
+)
+
+// ----------
+// Main
+// ----------
+
+func main() {
+	// A hardcoded signing secret would let anyone who reads the source
+	// forge admin tokens, so this has no fallback default.
+	jwtSecret = []byte(requireEnv("JWT_SECRET"))
+
+	// Connect to PostgreSQL.
+	// Adjust the DSN as appropriate for your setup.
+	dsn := "host=localhost user=postgres password=123456 dbname=postgres port=5432 sslmode=disable TimeZone=UTC"
+	var err error
+	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	// Auto-migrate the schema.
+	if err := db.AutoMigrate(&models.User{}, &models.Problem{}, &models.TestCase{}, &models.ProblemVersion{}, &models.Submission{}); err != nil {
+		log.Fatalf("failed to migrate: %v", err)
+	}
+
+	// Seed the bootstrap admin account and sample problems if none exist.
+	seedAdmin()
+	seedData()
+
+	// Connect to the job queue. Submissions are enqueued here and picked
+	// up by one or more `cmd/runner` processes.
+	redisAddr := "localhost:6379"
+	queueClient = queue.NewClient(redisAddr)
+	defer queueClient.Close()
+
+	// Connect to the object store used for large test-case payloads and
+	// bulk test-case uploads. Submission code and output are not stored
+	// there.
+	objectStore, err = storage.New(context.Background(), "localhost:9000", "minioadmin", "minioadmin", "sd-leetcode", false)
+	if err != nil {
+		log.Fatalf("failed to connect to object storage: %v", err)
+	}
+
+	// Create Gin router.
+	router := gin.Default()
+
+	// Load external HTML templates from the templates folder.
+	router.LoadHTMLGlob("templates/*")
+
+	// Frontend routes.
+	router.GET("/", getIndexPage)
+	router.GET("/problem/:id", getProblemPage)
+
+	// API routes.
+	api := router.Group("/api")
+	{
+		api.POST("/auth/register", apiRegister)
+		api.POST("/auth/login", apiLogin)
+
+		api.GET("/problems", apiGetProblems)
+		api.GET("/tags", apiGetTags)
+		api.GET("/problems/:id", apiGetProblem)
+		api.POST("/problems/:id/submit", auth.RequireAuth(jwtSecret), apiSubmitProblem)
+		api.GET("/check/:id", apiCheckSubmission)
+		api.GET("/leaderboard/:competitionId", apiLeaderboard)
+
+		admin := api.Group("/", auth.RequireAuth(jwtSecret), auth.RequireAdmin())
+		{
+			admin.POST("/problems", apiCreateProblem)
+			admin.PATCH("/problems/:id", apiUpdateProblem)
+			admin.POST("/problems/:id/versions", apiCreateProblemVersion)
+			admin.PATCH("/problems/:id/versions/:v", apiToggleProblemVersion)
+			admin.POST("/admin/rejudge", apiRejudge)
+			admin.POST("/problems/:id/testcases", apiCreateTestCase)
+			admin.POST("/problems/:id/testcases/upload", apiPresignTestCaseUpload)
+		}
+	}
+
+	// Start the server.
+	router.Run(":8080")
+}
+
+// ----------
+// Frontend Handlers
+// ----------
+
+// getIndexPage renders a simple index page with the list of problems.
+func getIndexPage(c *gin.Context) {
+	var problems []models.Problem
+	if err := db.Find(&problems).Error; err != nil {
+		c.String(http.StatusInternalServerError, "Error loading problems")
+		return
+	}
+	c.HTML(http.StatusOK, "index.html", gin.H{
+		"Problems": problems,
+	})
+}
+
+// getProblemPage renders the problem detail page with a submission form.
+func getProblemPage(c *gin.Context) {
+	id := c.Param("id")
+	var problem models.Problem
+	if err := db.Preload("TestCases").First(&problem, id).Error; err != nil {
+		c.String(http.StatusNotFound, "Problem not found")
+		return
+	}
+	// Get default code stub for python.
+	var stubs map[string]string
+	if err := json.Unmarshal(problem.CodeStubs, &stubs); err != nil {
+		stubs = map[string]string{"python": ""}
+	}
+	codeStub := stubs["python"]
+
+	c.HTML(http.StatusOK, "problem.html", gin.H{
+		"Problem":  problem,
+		"CodeStub": codeStub,
+	})
+}
+
+// ----------
+// API Handlers
+// ----------
+
+// apiGetProblems returns a paginated, filterable list of problems. Supported
+// query params: page, limit, level (exact match), q (fuzzy match against
+// title/question), and tags (comma-separated, fuzzy matched against the
+// problem's tag list). The response includes a total count so the frontend
+// can build paging controls instead of guessing from a capped page size.
+func apiGetProblems(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 100
+	}
+
+	query := db.Model(&models.Problem{})
+	if level := c.Query("level"); level != "" {
+		query = query.Where("level = ?", level)
+	}
+	if q := c.Query("q"); q != "" {
+		like := "%" + q + "%"
+		query = query.Where("title ILIKE ? OR question ILIKE ?", like, like)
+	}
+	if tagsParam := c.Query("tags"); tagsParam != "" {
+		var queryTags []string
+		for _, tag := range strings.Split(tagsParam, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				queryTags = append(queryTags, tag)
+			}
+		}
+		if len(queryTags) > 0 {
+			ids, err := problemIDsMatchingTags(query, queryTags)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch problems"})
+				return
+			}
+			query = query.Where("id IN ?", ids)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch problems"})
+		return
+	}
+
+	var problems []models.Problem
+	if err := query.Offset((page - 1) * limit).Limit(limit).Find(&problems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch problems"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": problems,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// apiGetTags returns every distinct tag in use across all problems along
+// with how many problems carry it, so the frontend can build a tag filter
+// list without shipping the full problem set to the client.
+func apiGetTags(c *gin.Context) {
+	var problems []models.Problem
+	if err := db.Select("tags").Find(&problems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch tags"})
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, p := range problems {
+		var tags []string
+		if err := json.Unmarshal(p.Tags, &tags); err != nil {
+			continue
+		}
+		for _, tag := range tags {
+			counts[tag]++
+		}
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// problemIDsMatchingTags evaluates fuzzy tag matching in Go instead of
+// SQL: tagFuzzyMatches does abbreviation matching (e.g. "dp" matches
+// "dynamic-programming") that a plain ILIKE can't express. query carries
+// the caller's other filters (level, q) so the candidate set respects
+// them too.
+func problemIDsMatchingTags(query *gorm.DB, queryTags []string) ([]uint, error) {
+	// query is reused by the caller for Count/Offset/Find after this
+	// returns, so the candidate scan must run on a cloned session -
+	// otherwise Select("id", "tags") mutates the shared statement and
+	// the caller's later Find only comes back with those two columns.
+	var candidates []models.Problem
+	if err := query.Session(&gorm.Session{}).Select("id", "tags").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	var ids []uint
+	for _, p := range candidates {
+		var tags []string
+		if err := json.Unmarshal(p.Tags, &tags); err != nil {
+			continue
+		}
+		if allTagsMatch(tags, queryTags) {
+			ids = append(ids, p.ID)
+		}
+	}
+	if ids == nil {
+		// No problem ID is ever 0, so this keeps the `id IN ?` clause
+		// valid while matching nothing.
+		ids = []uint{0}
+	}
+	return ids, nil
+}
+
+// allTagsMatch reports whether every entry in queryTags fuzzy-matches at
+// least one of the problem's tags.
+func allTagsMatch(tags, queryTags []string) bool {
+	for _, q := range queryTags {
+		matched := false
+		for _, t := range tags {
+			if tagFuzzyMatches(t, q) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// tagFuzzyMatches reports whether query fuzzy-matches tag: either as a
+// plain substring, or as the abbreviation formed by the first letter of
+// each hyphen-separated word in tag (e.g. "dp" matches
+// "dynamic-programming").
+func tagFuzzyMatches(tag, query string) bool {
+	tag = strings.ToLower(tag)
+	query = strings.ToLower(query)
+	if strings.Contains(tag, query) {
+		return true
+	}
+
+	var initials strings.Builder
+	for _, word := range strings.Split(tag, "-") {
+		if word != "" {
+			initials.WriteByte(word[0])
+		}
+	}
+	return initials.String() == query
+}
+
+// apiGetProblem returns the full details of a problem.
+// Optionally, a query parameter "language" can be used to pick a code stub.
+func apiGetProblem(c *gin.Context) {
+	id := c.Param("id")
+	var problem models.Problem
+	if err := db.Preload("TestCases").First(&problem, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+
+	lang := c.Query("language")
+	if lang == "" {
+		lang = "python"
+	}
+	var stubs map[string]string
+	if err := json.Unmarshal(problem.CodeStubs, &stubs); err != nil {
+		stubs = map[string]string{}
+	}
+	codeStub := stubs[lang]
+
+	c.JSON(http.StatusOK, gin.H{
+		"problem":  problem,
+		"codeStub": codeStub,
+	})
+}
+
+// apiSubmitProblem accepts a submission, persists it, and enqueues a job
+// for a runner to execute it.
+func apiSubmitProblem(c *gin.Context) {
+	id := c.Param("id")
+	// Make sure the problem exists.
+	var problem models.Problem
+	if err := db.First(&problem, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+
+	// Submissions are judged against a specific, enabled ProblemVersion
+	// so that editing test cases never changes the outcome of past runs.
+	version, err := latestEnabledVersion(problem.ID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "No enabled version available for this problem"})
+		return
+	}
+
+	// The authenticated user's ID comes from the JWT validated by
+	// auth.RequireAuth, not a client-supplied header.
+	authUserID, _ := c.Get(auth.ContextUserIDKey)
+	userID := strconv.Itoa(int(authUserID.(uint)))
+
+	var payload struct {
+		Code     string `json:"code"`
+		Language string `json:"language"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	sub := models.Submission{
+		ProblemID:        problem.ID,
+		ProblemVersionID: version.ID,
+		UserID:           userID,
+		Code:             payload.Code,
+		Language:         payload.Language,
+		CompetitionID:    "comp1", // for demo purposes, all submissions belong to "comp1"
+		Status:           "pending",
+		CreatedAt:        time.Now(),
+	}
+	if err := db.Create(&sub).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not save submission"})
+		return
+	}
+
+	// Enqueue the submission for a runner to pick up. The runner is
+	// responsible for updating the submission's status once it's done.
+	if _, err := queue.EnqueueSubmission(queueClient, queue.SubmissionJob{
+		SubmissionID:     sub.ID,
+		ProblemID:        problem.ID,
+		ProblemVersionID: version.ID,
+		Language:         sub.Language,
+		Code:             sub.Code,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not enqueue submission"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"submissionId": sub.ID,
+		"status":       sub.Status,
+	})
+}
+
+// apiCreateProblem creates a new problem. Admin-only.
+func apiCreateProblem(c *gin.Context) {
+	var payload struct {
+		Title        string         `json:"title"`
+		Question     string         `json:"question"`
+		Level        string         `json:"level"`
+		Tags         datatypes.JSON `json:"tags"`
+		CodeStubs    datatypes.JSON `json:"codeStubs"`
+		FunctionName string         `json:"functionName"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	problem := models.Problem{
+		Title:        payload.Title,
+		Question:     payload.Question,
+		Level:        payload.Level,
+		Tags:         payload.Tags,
+		CodeStubs:    payload.CodeStubs,
+		FunctionName: payload.FunctionName,
+	}
+	if err := db.Create(&problem).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create problem"})
+		return
+	}
+	c.JSON(http.StatusOK, problem)
+}
+
+// apiUpdateProblem updates an existing problem's fields. Admin-only.
+func apiUpdateProblem(c *gin.Context) {
+	id := c.Param("id")
+	var problem models.Problem
+	if err := db.First(&problem, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+
+	var payload struct {
+		Title        *string         `json:"title"`
+		Question     *string         `json:"question"`
+		Level        *string         `json:"level"`
+		Tags         *datatypes.JSON `json:"tags"`
+		CodeStubs    *datatypes.JSON `json:"codeStubs"`
+		FunctionName *string         `json:"functionName"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	if payload.Title != nil {
+		problem.Title = *payload.Title
+	}
+	if payload.Question != nil {
+		problem.Question = *payload.Question
+	}
+	if payload.Level != nil {
+		problem.Level = *payload.Level
+	}
+	if payload.Tags != nil {
+		problem.Tags = *payload.Tags
+	}
+	if payload.CodeStubs != nil {
+		problem.CodeStubs = *payload.CodeStubs
+	}
+	if payload.FunctionName != nil {
+		problem.FunctionName = *payload.FunctionName
+	}
+
+	if err := db.Save(&problem).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update problem"})
+		return
+	}
+	c.JSON(http.StatusOK, problem)
+}
+
+// apiCheckSubmission allows clients to poll for the result of a submission.
+func apiCheckSubmission(c *gin.Context) {
+	id := c.Param("id")
+	var sub models.Submission
+	if err := db.First(&sub, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// apiLeaderboard returns a simple leaderboard for a given competition.
+func apiLeaderboard(c *gin.Context) {
+	competitionID := c.Param("competitionId")
+	// Count all submissions that are completed and passed.
+	type LBEntry struct {
+		UserID    string
+		NumSolved int
+	}
+	rows, err := db.Model(&models.Submission{}).
+		Select("user_id, COUNT(*) as num_solved").
+		Where("competition_id = ? AND status = ? AND passed = ?", competitionID, "completed", true).
+		Group("user_id").Rows()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching leaderboard"})
+		return
+	}
+	defer rows.Close()
+	var leaderboard []LBEntry
+	for rows.Next() {
+		var entry LBEntry
+		if err := rows.Scan(&entry.UserID, &entry.NumSolved); err == nil {
+			leaderboard = append(leaderboard, entry)
+		}
+	}
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+// ----------
+// Helpers
+// ----------
+
+// requireEnv returns the value of the given environment variable, or
+// fails fast at startup if it isn't set. Used for secrets that must
+// never silently fall back to a hardcoded default.
+func requireEnv(key string) string {
+	val := os.Getenv(key)
+	if val == "" {
+		log.Fatalf("%s environment variable is required", key)
+	}
+	return val
+}
+
+// seedData creates sample problems if none exist.
+func seedData() {
+	var count int64
+	db.Model(&models.Problem{}).Count(&count)
+	if count > 0 {
+		return
+	}
+
+	// Create sample Problem #1: Two Sum.
+	tags1, _ := json.Marshal([]string{"array", "hash-table"})
+	stubs1, _ := json.Marshal(map[string]string{
+		"python":     "def twoSum(nums, target):\n    pass",
+		"javascript": "function twoSum(nums, target) {\n    // TODO\n}",
+	})
+	prob1 := models.Problem{
+		Title:        "Two Sum",
+		Question:     "Given an array of integers, return indices of the two numbers such that they add up to a specific target.",
+		Level:        "Easy",
+		Tags:         datatypes.JSON(tags1),
+		CodeStubs:    datatypes.JSON(stubs1),
+		FunctionName: "twoSum", // Store the function name to call.
+	}
+	db.Create(&prob1)
+
+	// Add a test case for problem #1.
+	input1, _ := json.Marshal(map[string]interface{}{"nums": []int{2, 7, 11, 15}, "target": 9})
+	output1, _ := json.Marshal([]int{0, 1})
+	tc1 := models.TestCase{
+		ProblemID: prob1.ID,
+		Type:      "default",
+		Input:     datatypes.JSON(input1),
+		Output:    datatypes.JSON(output1),
+	}
+	db.Create(&tc1)
+	createInitialVersion(prob1, []models.TestCase{tc1})
+
+	// Create sample Problem #2: Reverse String.
+	tags2, _ := json.Marshal([]string{"string", "two-pointers"})
+	stubs2, _ := json.Marshal(map[string]string{
+		"python":     "def reverseString(s):\n    pass",
+		"javascript": "function reverseString(s) {\n    // TODO\n}",
+	})
+	prob2 := models.Problem{
+		Title:        "Reverse String",
+		Question:     "Write a function that reverses a string.",
+		Level:        "Easy",
+		Tags:         datatypes.JSON(tags2),
+		CodeStubs:    datatypes.JSON(stubs2),
+		FunctionName: "reverseString", // Store the function name to call.
+	}
+	db.Create(&prob2)
+
+	// Add a test case for problem #2.
+	input2, _ := json.Marshal("hello")
+	output2, _ := json.Marshal("olleh")
+	tc2 := models.TestCase{
+		ProblemID: prob2.ID,
+		Type:      "default",
+		Input:     datatypes.JSON(input2),
+		Output:    datatypes.JSON(output2),
+	}
+	db.Create(&tc2)
+	createInitialVersion(prob2, []models.TestCase{tc2})
+}
+
+// createInitialVersion snapshots a freshly seeded problem's test cases
+// into its first, enabled ProblemVersion.
+func createInitialVersion(problem models.Problem, testCases []models.TestCase) {
+	snapshot := make([]models.VersionedTestCase, 0, len(testCases))
+	for _, tc := range testCases {
+		snapshot = append(snapshot, models.VersionedTestCase{
+			Input:     tc.Input,
+			Output:    tc.Output,
+			InputKey:  tc.InputKey,
+			OutputKey: tc.OutputKey,
+		})
+	}
+	testCasesJSON, _ := json.Marshal(snapshot)
+	db.Create(&models.ProblemVersion{
+		ProblemID:    problem.ID,
+		Version:      1,
+		IsEnabled:    true,
+		TestCases:    testCasesJSON,
+		FunctionName: problem.FunctionName,
+		CreatedAt:    time.Now(),
+	})
+}
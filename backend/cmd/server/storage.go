@@ -0,0 +1,105 @@
+// cmd/server/storage.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+
+	"sd-leetcode/backend/internal/models"
+	"sd-leetcode/backend/internal/storage"
+)
+
+const testCaseUploadURLTTL = 15 * time.Minute
+
+// apiPresignTestCaseUpload returns a presigned URL an admin can PUT a
+// bulk test-case archive to directly, so large archives never have to
+// round-trip through the API server.
+func apiPresignTestCaseUpload(c *gin.Context) {
+	id := c.Param("id")
+	var problem models.Problem
+	if err := db.First(&problem, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+
+	key := fmt.Sprintf("problems/%d/testcases/%d.tar.gz", problem.ID, time.Now().UnixNano())
+	url, err := objectStore.PresignedPutURL(c.Request.Context(), key, testCaseUploadURLTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not generate upload URL"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"uploadUrl": url,
+		"key":       key,
+		"expiresIn": int(testCaseUploadURLTTL.Seconds()),
+	})
+}
+
+// apiCreateTestCase adds a single test case to a problem. Its input and
+// output are stored inline unless either exceeds
+// storage.InlineThresholdBytes, in which case it's offloaded to object
+// storage and TestCase.InputKey/OutputKey records the key instead.
+func apiCreateTestCase(c *gin.Context) {
+	id := c.Param("id")
+	var problem models.Problem
+	if err := db.First(&problem, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+
+	var payload struct {
+		Type   string          `json:"type"`
+		Input  json.RawMessage `json:"input"`
+		Output json.RawMessage `json:"output"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	tc := models.TestCase{ProblemID: problem.ID, Type: payload.Type}
+
+	inputKey := fmt.Sprintf("problems/%d/testcases/%d-input.json", problem.ID, time.Now().UnixNano())
+	inline, key, err := offloadIfLarge(c.Request.Context(), inputKey, payload.Input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not store test case input"})
+		return
+	}
+	tc.Input, tc.InputKey = inline, key
+
+	outputKey := fmt.Sprintf("problems/%d/testcases/%d-output.json", problem.ID, time.Now().UnixNano())
+	inline, key, err = offloadIfLarge(c.Request.Context(), outputKey, payload.Output)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not store test case output"})
+		return
+	}
+	tc.Output, tc.OutputKey = inline, key
+
+	if err := db.Create(&tc).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create test case"})
+		return
+	}
+	c.JSON(http.StatusOK, tc)
+}
+
+// offloadIfLarge stores data in object storage under key when it exceeds
+// storage.InlineThresholdBytes, returning the key to persist on
+// InputKey/OutputKey. Otherwise it returns data unchanged to be stored
+// inline as datatypes.JSON.
+func offloadIfLarge(ctx context.Context, key string, data []byte) (datatypes.JSON, string, error) {
+	if len(data) <= storage.InlineThresholdBytes {
+		return datatypes.JSON(data), "", nil
+	}
+	if err := objectStore.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "application/json"); err != nil {
+		return nil, "", err
+	}
+	return nil, key, nil
+}
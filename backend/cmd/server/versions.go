@@ -0,0 +1,179 @@
+// cmd/server/versions.go
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"sd-leetcode/backend/internal/models"
+	"sd-leetcode/backend/internal/queue"
+)
+
+// latestEnabledVersion returns the newest enabled ProblemVersion for a
+// problem, or an error if none exists.
+func latestEnabledVersion(problemID uint) (models.ProblemVersion, error) {
+	var version models.ProblemVersion
+	err := db.Where("problem_id = ? AND is_enabled = ?", problemID, true).
+		Order("version DESC").
+		First(&version).Error
+	return version, err
+}
+
+// apiCreateProblemVersion snapshots the problem's current test cases into
+// a new, enabled ProblemVersion so future submissions judge against it
+// without disturbing the history of older versions.
+func apiCreateProblemVersion(c *gin.Context) {
+	id := c.Param("id")
+	var problem models.Problem
+	if err := db.Preload("TestCases").First(&problem, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+
+	var payload struct {
+		FunctionName string `json:"functionName"`
+		TimeLimit    int    `json:"timeLimit"`
+		MemoryLimit  int    `json:"memoryLimit"`
+	}
+	// Payload is optional; fall back to the problem's current settings.
+	_ = c.ShouldBindJSON(&payload)
+	if payload.FunctionName == "" {
+		payload.FunctionName = problem.FunctionName
+	}
+
+	snapshot := make([]models.VersionedTestCase, 0, len(problem.TestCases))
+	for _, tc := range problem.TestCases {
+		snapshot = append(snapshot, models.VersionedTestCase{
+			Input:     tc.Input,
+			Output:    tc.Output,
+			InputKey:  tc.InputKey,
+			OutputKey: tc.OutputKey,
+		})
+	}
+	testCasesJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not snapshot test cases"})
+		return
+	}
+
+	var lastVersion int
+	db.Model(&models.ProblemVersion{}).Where("problem_id = ?", problem.ID).
+		Select("COALESCE(MAX(version), 0)").Scan(&lastVersion)
+
+	version := models.ProblemVersion{
+		ProblemID:    problem.ID,
+		Version:      lastVersion + 1,
+		IsEnabled:    true,
+		TestCases:    testCasesJSON,
+		FunctionName: payload.FunctionName,
+		TimeLimit:    payload.TimeLimit,
+		MemoryLimit:  payload.MemoryLimit,
+		CreatedAt:    time.Now(),
+	}
+	if err := db.Create(&version).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create version"})
+		return
+	}
+	c.JSON(http.StatusOK, version)
+}
+
+// apiToggleProblemVersion enables or disables a specific problem version.
+func apiToggleProblemVersion(c *gin.Context) {
+	problemID := c.Param("id")
+	versionNum, err := strconv.Atoi(c.Param("v"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	var payload struct {
+		IsEnabled bool `json:"isEnabled"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	var version models.ProblemVersion
+	if err := db.Where("problem_id = ? AND version = ?", problemID, versionNum).First(&version).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Version not found"})
+		return
+	}
+
+	version.IsEnabled = payload.IsEnabled
+	if err := db.Save(&version).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update version"})
+		return
+	}
+	c.JSON(http.StatusOK, version)
+}
+
+// apiRejudge re-enqueues submissions for a problem so they're executed
+// again against targetVersionId (or the problem's latest enabled version,
+// if omitted) instead of the stale version they originally ran against -
+// otherwise "fixing" a buggy test case by cutting a new version would
+// never change the outcome of a rejudge.
+func apiRejudge(c *gin.Context) {
+	var payload struct {
+		ProblemID        uint `json:"problemId"`
+		ProblemVersionID uint `json:"problemVersionId"` // optional: only rejudge submissions last judged against this version
+		TargetVersionID  uint `json:"targetVersionId"`  // optional: version to judge against now
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	var targetVersion models.ProblemVersion
+	if payload.TargetVersionID != 0 {
+		if err := db.First(&targetVersion, payload.TargetVersionID).Error; err != nil || !targetVersion.IsEnabled {
+			c.JSON(http.StatusConflict, gin.H{"error": "Target version not found or not enabled"})
+			return
+		}
+		if targetVersion.ProblemID != payload.ProblemID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Target version does not belong to problemId"})
+			return
+		}
+	} else {
+		var err error
+		targetVersion, err = latestEnabledVersion(payload.ProblemID)
+		if err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "No enabled version available for this problem"})
+			return
+		}
+	}
+
+	var submissions []models.Submission
+	query := db.Where("problem_id = ?", payload.ProblemID)
+	if payload.ProblemVersionID != 0 {
+		query = query.Where("problem_version_id = ?", payload.ProblemVersionID)
+	}
+	if err := query.Find(&submissions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not load submissions"})
+		return
+	}
+
+	requeued := 0
+	for _, sub := range submissions {
+		sub.Status = "pending"
+		sub.ProblemVersionID = targetVersion.ID
+		if err := db.Save(&sub).Error; err != nil {
+			continue
+		}
+		if _, err := queue.EnqueueSubmission(queueClient, queue.SubmissionJob{
+			SubmissionID:     sub.ID,
+			ProblemID:        sub.ProblemID,
+			ProblemVersionID: targetVersion.ID,
+			Language:         sub.Language,
+			Code:             sub.Code,
+		}); err == nil {
+			requeued++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requeued": requeued, "total": len(submissions), "targetVersionId": targetVersion.ID})
+}
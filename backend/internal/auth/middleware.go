@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextUserIDKey and ContextRoleKey are the gin.Context keys the
+// RequireAuth middleware sets once a token has been validated.
+const (
+	ContextUserIDKey = "userID"
+	ContextRoleKey   = "role"
+)
+
+// RequireAuth validates the Authorization: Bearer <token> header and
+// injects the authenticated user's ID and role into the request context.
+// Requests without a valid token are rejected with 401.
+func RequireAuth(secret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or malformed Authorization header"})
+			return
+		}
+
+		claims, err := ParseToken(secret, parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		c.Set(ContextUserIDKey, claims.UserID)
+		c.Set(ContextRoleKey, claims.Role)
+		c.Next()
+	}
+}
+
+// RequireAdmin rejects the request unless RequireAuth has already run and
+// the authenticated user's role is RoleAdmin.
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get(ContextRoleKey)
+		if role != RoleAdmin {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin role required"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+// Package auth issues and validates the JWTs that replace the old
+// X-User-ID header trust model, plus the Gin middleware that injects the
+// authenticated user into the request context.
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RoleAdmin and RoleUser are the only two roles the app currently knows
+// about. Admin-only routes check for RoleAdmin explicitly.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Claims are the JWT claims issued on login/register.
+type Claims struct {
+	UserID uint   `json:"userId"`
+	Role   string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// ErrInvalidToken is returned when a token fails signature or claim
+// validation.
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// GenerateToken signs a JWT for userID/role valid for ttl.
+func GenerateToken(secret []byte, userID uint, role string, ttl time.Duration) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secret)
+}
+
+// ParseToken validates a signed token string and returns its claims.
+func ParseToken(secret []byte, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
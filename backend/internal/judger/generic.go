@@ -0,0 +1,153 @@
+package judger
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// runGeneric executes a Go, C++, or Java submission by writing the user's
+// code into a tempdir bind-mounted into the container, the same
+// mount-and-attach approach as runPython/runJavaScript. Unlike those two,
+// there's no harness here to resolve functionName by name: the submission
+// itself must read the test case's JSON from stdin and print the result,
+// so functionName is accepted but unused. Compiled languages run
+// cfg.CompileCmd first, with a non-zero exit there classified as CE; the
+// test case's JSON input is then piped into cfg.RunCmd's stdin over a
+// live container attach.
+func (j *Judger) runGeneric(ctx context.Context, cfg LanguageConfig, code, functionName, testInputJSON string) (TestResult, error) {
+	workDir, err := ioutil.TempDir("/dev/shm", "judger-run-")
+	if err != nil {
+		// /dev/shm (tmpfs) isn't available on every host; fall back to
+		// the regular tempdir rather than failing the submission.
+		workDir, err = ioutil.TempDir("", "judger-run-")
+		if err != nil {
+			return TestResult{Verdict: RE}, err
+		}
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := ioutil.WriteFile(filepath.Join(workDir, cfg.SourceFile), []byte(code), 0644); err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+
+	start := time.Now()
+
+	if len(cfg.CompileCmd) > 0 {
+		// The compiler needs to write its own caches/temp files outside
+		// /workspace (e.g. GOCACHE), so the rootfs stays writable here;
+		// only the run step below locks it down.
+		exitCode, _, stderr, timedOut, err := j.runStep(ctx, cfg, cfg.CompileCmd, workDir, "", false)
+		if err != nil {
+			return TestResult{Verdict: RE}, err
+		}
+		if timedOut {
+			return TestResult{Verdict: TLE, TimeMs: time.Since(start).Milliseconds()}, nil
+		}
+		if exitCode != 0 {
+			return TestResult{Verdict: CE, Output: string(stderr), TimeMs: time.Since(start).Milliseconds()}, nil
+		}
+	}
+
+	exitCode, stdout, stderr, timedOut, err := j.runStep(ctx, cfg, cfg.RunCmd(functionName), workDir, testInputJSON, true)
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		return TestResult{Verdict: RE, TimeMs: elapsed}, err
+	}
+	if timedOut {
+		return TestResult{Verdict: TLE, TimeMs: elapsed}, nil
+	}
+	if exitCode == 137 {
+		// SIGKILL from the OOM killer when the container exceeds Memory.
+		return TestResult{Verdict: MLE, TimeMs: elapsed, MemoryKB: cfg.MemoryBytes / 1024}, nil
+	}
+	if exitCode != 0 {
+		return TestResult{Verdict: RE, Output: string(stderr), TimeMs: elapsed}, nil
+	}
+	return TestResult{Verdict: AC, Output: string(stdout), TimeMs: elapsed}, nil
+}
+
+// runStep creates, starts, and waits for a single container running cmd
+// with workDir bind-mounted at /workspace, optionally piping stdin into
+// it once started. It reports the exit code, captured stdout/stderr, and
+// whether ctx's deadline fired before the container finished.
+func (j *Judger) runStep(ctx context.Context, cfg LanguageConfig, cmd []string, workDir, stdin string, readonlyRootfs bool) (exitCode int64, stdout, stderr []byte, timedOut bool, err error) {
+	resp, err := j.cli.ContainerCreate(ctx, &container.Config{
+		Image:        cfg.Image,
+		Cmd:          cmd,
+		WorkingDir:   "/workspace",
+		OpenStdin:    true,
+		StdinOnce:    true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          false,
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{{
+			Type:   mount.TypeBind,
+			Source: workDir,
+			Target: "/workspace",
+		}},
+		Memory:          cfg.MemoryBytes,
+		NanoCPUs:        cfg.NanoCPUs,
+		PidsLimit:       &cfg.PidsLimit,
+		NetworkDisabled: true,
+		ReadonlyRootfs:  readonlyRootfs,
+	}, nil, nil, "")
+	if err != nil {
+		return 0, nil, nil, false, err
+	}
+	defer j.cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+
+	hijacked, err := j.cli.ContainerAttach(ctx, resp.ID, container.AttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return 0, nil, nil, false, err
+	}
+	defer hijacked.Close()
+
+	if err := j.cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return 0, nil, nil, false, err
+	}
+
+	if stdin != "" {
+		if _, err := hijacked.Conn.Write([]byte(stdin)); err != nil {
+			return 0, nil, nil, false, err
+		}
+	}
+	hijacked.CloseWrite()
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	copyDone := make(chan error, 1)
+	go func() {
+		_, copyErr := stdcopy.StdCopy(&stdoutBuf, &stderrBuf, hijacked.Reader)
+		copyDone <- copyErr
+	}()
+
+	statusCh, errCh := j.cli.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
+	select {
+	case waitErr := <-errCh:
+		if ctx.Err() == context.DeadlineExceeded {
+			return 0, nil, nil, true, nil
+		}
+		if waitErr != nil {
+			return 0, nil, nil, false, waitErr
+		}
+	case status := <-statusCh:
+		exitCode = status.StatusCode
+	}
+	<-copyDone
+
+	return exitCode, stdoutBuf.Bytes(), stderrBuf.Bytes(), false, nil
+}
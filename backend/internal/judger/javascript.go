@@ -0,0 +1,104 @@
+package judger
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// jsRunnerSource is generated alongside the user's submission. Unlike
+// Python, a JS function has no keyword-argument calling convention, so a
+// JSON object payload is mapped onto the function's declared parameter
+// names (read off Function.prototype.toString()) in order, instead of
+// python.go's `fn(**payload)`:
+//   - a JSON object becomes positional arguments ordered by parameter name
+//   - a JSON array becomes positional arguments, unpacked directly
+//   - anything else is passed through as the sole argument
+const jsRunnerSource = `const fs = require("fs");
+const vm = require("vm");
+
+function paramNames(fn) {
+    const match = fn.toString().match(/\(([^)]*)\)/);
+    if (!match) return [];
+    return match[1]
+        .split(",")
+        .map((p) => p.trim())
+        .filter((p) => p.length > 0);
+}
+
+function main() {
+    const functionName = process.argv[2];
+
+    const code = fs.readFileSync("solution.js", "utf8");
+    const sandbox = {};
+    vm.createContext(sandbox);
+    vm.runInContext(code, sandbox, { filename: "solution.js" });
+
+    const fn = sandbox[functionName];
+    if (typeof fn !== "function") {
+        throw new Error("function not found: " + functionName);
+    }
+
+    const payload = JSON.parse(fs.readFileSync(0, "utf8"));
+
+    let args;
+    if (Array.isArray(payload)) {
+        args = payload;
+    } else if (payload !== null && typeof payload === "object") {
+        args = paramNames(fn).map((name) => payload[name]);
+    } else {
+        args = [payload];
+    }
+
+    const result = fn(...args);
+    process.stdout.write(JSON.stringify(result === undefined ? null : result));
+}
+
+main();
+`
+
+// runJavaScript executes a JavaScript submission by writing the user's
+// code and a generated runner.js into a bind-mounted tempdir, same as
+// runPython. The runner loads solution.js in a vm context (so a bare
+// function declaration is reachable without requiring module.exports),
+// resolves functionName, and calls it with the test case's JSON piped
+// over stdin.
+func (j *Judger) runJavaScript(ctx context.Context, cfg LanguageConfig, code, functionName, testInputJSON string) (TestResult, error) {
+	workDir, err := ioutil.TempDir("/dev/shm", "judger-js-")
+	if err != nil {
+		// /dev/shm (tmpfs) isn't available on every host; fall back to
+		// the regular tempdir rather than failing the submission.
+		workDir, err = ioutil.TempDir("", "judger-js-")
+		if err != nil {
+			return TestResult{Verdict: RE}, err
+		}
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := ioutil.WriteFile(filepath.Join(workDir, "solution.js"), []byte(code), 0444); err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(workDir, "runner.js"), []byte(jsRunnerSource), 0444); err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+
+	start := time.Now()
+	exitCode, stdout, stderr, timedOut, err := j.runStep(ctx, cfg, []string{"node", "runner.js", functionName}, workDir, testInputJSON, true)
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		return TestResult{Verdict: RE, TimeMs: elapsed}, err
+	}
+	if timedOut {
+		return TestResult{Verdict: TLE, TimeMs: elapsed}, nil
+	}
+	if exitCode == 137 {
+		// SIGKILL from the OOM killer when the container exceeds Memory.
+		return TestResult{Verdict: MLE, TimeMs: elapsed, MemoryKB: cfg.MemoryBytes / 1024}, nil
+	}
+	if exitCode != 0 {
+		return TestResult{Verdict: RE, Output: string(stderr), TimeMs: elapsed}, nil
+	}
+	return TestResult{Verdict: AC, Output: string(stdout), TimeMs: elapsed}, nil
+}
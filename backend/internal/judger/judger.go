@@ -0,0 +1,166 @@
+// Package judger runs submitted code inside sandboxed Docker containers
+// and classifies the result into a verdict, replacing the old
+// Python-only executor with a per-language configurable harness.
+package judger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/docker/docker/client"
+)
+
+// Verdict classifies the outcome of running a submission against a
+// single test case.
+type Verdict string
+
+const (
+	AC  Verdict = "AC"  // Accepted
+	WA  Verdict = "WA"  // Wrong Answer
+	TLE Verdict = "TLE" // Time Limit Exceeded
+	MLE Verdict = "MLE" // Memory Limit Exceeded
+	RE  Verdict = "RE"  // Runtime Error
+	CE  Verdict = "CE"  // Compile Error
+)
+
+// LanguageConfig describes how to compile and run a submission in a
+// given language, along with the resource limits the sandbox enforces.
+// RunCmd is the default invocation; python and javascript actually run a
+// generated harness instead (see runPython/runJavaScript) and ignore it.
+type LanguageConfig struct {
+	Image        string        // Docker image used to compile/run the code
+	CompileCmd   []string      // empty for interpreted languages
+	RunCmd       func(functionName string) []string
+	MemoryBytes  int64         // HostConfig.Memory
+	NanoCPUs     int64         // HostConfig.NanoCPUs
+	PidsLimit    int64         // HostConfig.PidsLimit
+	WallTimeout  time.Duration // per-testcase timeout enforced via context
+	SourceFile   string        // filename the code is written to inside the container
+}
+
+// languages holds the supported language configs. Memory/CPU limits are
+// deliberately conservative defaults for a shared judging fleet.
+var languages = map[string]LanguageConfig{
+	"python": {
+		Image:       "python:3.8-slim",
+		SourceFile:  "solution.py",
+		RunCmd:      func(fn string) []string { return []string{"python3", "solution.py"} },
+		MemoryBytes: 256 * 1024 * 1024,
+		NanoCPUs:    1_000_000_000, // 1 vCPU
+		PidsLimit:   64,
+		WallTimeout: 5 * time.Second,
+	},
+	"javascript": {
+		Image:       "node:18-slim",
+		SourceFile:  "solution.js",
+		RunCmd:      func(fn string) []string { return []string{"node", "solution.js"} },
+		MemoryBytes: 256 * 1024 * 1024,
+		NanoCPUs:    1_000_000_000,
+		PidsLimit:   64,
+		WallTimeout: 5 * time.Second,
+	},
+	// go, cpp, and java have no code-stub convention or reflection-based
+	// generic call path the way python/javascript do, so they're judged
+	// as full programs: the submission itself must read the test case's
+	// JSON from stdin and print the result to stdout. functionName is
+	// accepted for these but unused.
+	"go": {
+		Image:       "golang:1.21-alpine",
+		SourceFile:  "solution.go",
+		CompileCmd:  []string{"go", "build", "-o", "solution", "solution.go"},
+		RunCmd:      func(fn string) []string { return []string{"./solution"} },
+		MemoryBytes: 512 * 1024 * 1024,
+		NanoCPUs:    1_000_000_000,
+		PidsLimit:   64,
+		WallTimeout: 5 * time.Second,
+	},
+	"cpp": {
+		Image:       "gcc:13",
+		SourceFile:  "solution.cpp",
+		CompileCmd:  []string{"g++", "-O2", "-o", "solution", "solution.cpp"},
+		RunCmd:      func(fn string) []string { return []string{"./solution"} },
+		MemoryBytes: 256 * 1024 * 1024,
+		NanoCPUs:    1_000_000_000,
+		PidsLimit:   64,
+		WallTimeout: 3 * time.Second,
+	},
+	"java": {
+		Image:       "openjdk:17-slim",
+		SourceFile:  "Solution.java",
+		CompileCmd:  []string{"javac", "Solution.java"},
+		RunCmd:      func(fn string) []string { return []string{"java", "Solution"} },
+		MemoryBytes: 512 * 1024 * 1024,
+		NanoCPUs:    1_000_000_000,
+		PidsLimit:   64,
+		WallTimeout: 8 * time.Second,
+	},
+}
+
+// ConfigFor returns the LanguageConfig for a given language, and whether
+// it is supported.
+func ConfigFor(language string) (LanguageConfig, bool) {
+	cfg, ok := languages[language]
+	return cfg, ok
+}
+
+// TestResult is the outcome of running a submission against a single
+// test case.
+type TestResult struct {
+	Verdict  Verdict `json:"verdict"`
+	Output   string  `json:"output"`
+	TimeMs   int64   `json:"timeMs"`
+	MemoryKB int64   `json:"memoryKb"`
+}
+
+// Judger runs submissions inside Docker containers.
+type Judger struct {
+	cli *client.Client
+}
+
+// New connects to the Docker daemon and returns a Judger.
+func New() (*Judger, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+	return &Judger{cli: cli}, nil
+}
+
+// Run compiles (if needed) and executes code for the given language
+// against a single test case, enforcing the language's resource limits
+// and wall-clock timeout.
+func (j *Judger) Run(ctx context.Context, language, code, functionName, testInput string) (TestResult, error) {
+	cfg, ok := ConfigFor(language)
+	if !ok {
+		return TestResult{}, fmt.Errorf("unsupported language: %s", language)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.WallTimeout)
+	defer cancel()
+
+	// Python and JavaScript generate a small harness that resolves
+	// functionName and calls it with the test case's JSON (see
+	// python.go/javascript.go). go/cpp/java have no such harness and are
+	// judged as full stdin-to-stdout programs instead (see generic.go),
+	// additionally running CompileCmd first when the language needs
+	// compiling.
+	switch language {
+	case "python":
+		return j.runPython(ctx, cfg, code, functionName, testInput)
+	case "javascript":
+		return j.runJavaScript(ctx, cfg, code, functionName, testInput)
+	}
+
+	result, err := j.runGeneric(ctx, cfg, code, functionName, testInput)
+	if err == nil {
+		log.Printf("judger: language=%s verdict=%s elapsed=%dms", language, result.Verdict, result.TimeMs)
+	}
+	return result, err
+}
+
+// Close releases the underlying Docker client.
+func (j *Judger) Close() error {
+	return j.cli.Close()
+}
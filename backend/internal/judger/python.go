@@ -0,0 +1,159 @@
+package judger
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// pythonRunnerSource is generated alongside the user's submission. It
+// reads the test case input as a single JSON value from stdin and calls
+// the target function with it, instead of the old approach of
+// interpolating the whole JSON blob as one quoted string argument:
+//   - a JSON object becomes keyword arguments (`fn(**payload)`)
+//   - a JSON array becomes positional arguments (`fn(*payload)`)
+//   - anything else is passed through as the sole argument
+const pythonRunnerSource = `import argparse
+import json
+import sys
+
+import solution
+
+
+def main():
+    parser = argparse.ArgumentParser()
+    parser.add_argument("--function", required=True)
+    args = parser.parse_args()
+
+    payload = json.loads(sys.stdin.read())
+    fn = getattr(solution, args.function)
+
+    if isinstance(payload, dict):
+        result = fn(**payload)
+    elif isinstance(payload, list):
+        result = fn(*payload)
+    else:
+        result = fn(payload)
+
+    print(json.dumps(result))
+
+
+if __name__ == "__main__":
+    main()
+`
+
+// runPython executes a Python submission by writing the user's code and a
+// generated runner.py into a tmpfs-backed tempdir that gets bind-mounted
+// read-only into the container, then piping the test case's JSON input
+// into the runner's stdin over a live container attach. This replaces the
+// old `exec('''...''')` string concatenation, which broke on any ''' in
+// user code and passed the whole test case as one opaque quoted string
+// instead of typed arguments.
+func (j *Judger) runPython(ctx context.Context, cfg LanguageConfig, code, functionName, testInputJSON string) (TestResult, error) {
+	workDir, err := ioutil.TempDir("/dev/shm", "judger-py-")
+	if err != nil {
+		// /dev/shm (tmpfs) isn't available on every host; fall back to
+		// the regular tempdir rather than failing the submission.
+		workDir, err = ioutil.TempDir("", "judger-py-")
+		if err != nil {
+			return TestResult{Verdict: RE}, err
+		}
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := ioutil.WriteFile(filepath.Join(workDir, "solution.py"), []byte(code), 0444); err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(workDir, "runner.py"), []byte(pythonRunnerSource), 0444); err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+
+	start := time.Now()
+	resp, err := j.cli.ContainerCreate(ctx, &container.Config{
+		Image:        cfg.Image,
+		Cmd:          []string{"python3", "runner.py", "--function", functionName},
+		WorkingDir:   "/workspace",
+		OpenStdin:    true,
+		StdinOnce:    true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          false,
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{{
+			Type:     mount.TypeBind,
+			Source:   workDir,
+			Target:   "/workspace",
+			ReadOnly: true,
+		}},
+		Memory:          cfg.MemoryBytes,
+		NanoCPUs:        cfg.NanoCPUs,
+		PidsLimit:       &cfg.PidsLimit,
+		NetworkDisabled: true,
+		ReadonlyRootfs:  true,
+	}, nil, nil, "")
+	if err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+	defer j.cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+
+	hijacked, err := j.cli.ContainerAttach(ctx, resp.ID, container.AttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+	defer hijacked.Close()
+
+	if err := j.cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+
+	if _, err := hijacked.Conn.Write([]byte(testInputJSON)); err != nil {
+		return TestResult{Verdict: RE}, err
+	}
+	hijacked.CloseWrite()
+
+	var stdout, stderr bytes.Buffer
+	copyDone := make(chan error, 1)
+	go func() {
+		_, copyErr := stdcopy.StdCopy(&stdout, &stderr, hijacked.Reader)
+		copyDone <- copyErr
+	}()
+
+	statusCh, errCh := j.cli.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
+	var exitCode int64
+	select {
+	case err := <-errCh:
+		if ctx.Err() == context.DeadlineExceeded {
+			return TestResult{Verdict: TLE, TimeMs: time.Since(start).Milliseconds()}, nil
+		}
+		if err != nil {
+			return TestResult{Verdict: RE}, err
+		}
+	case status := <-statusCh:
+		exitCode = status.StatusCode
+	}
+	<-copyDone
+	elapsed := time.Since(start).Milliseconds()
+
+	if exitCode == 137 {
+		// SIGKILL from the OOM killer when the container exceeds Memory.
+		return TestResult{Verdict: MLE, TimeMs: elapsed, MemoryKB: cfg.MemoryBytes / 1024}, nil
+	}
+	if exitCode != 0 {
+		return TestResult{Verdict: RE, Output: stderr.String(), TimeMs: elapsed}, nil
+	}
+
+	return TestResult{Verdict: AC, Output: stdout.String(), TimeMs: elapsed}, nil
+}
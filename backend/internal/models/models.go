@@ -0,0 +1,97 @@
+// Package models holds the GORM models shared between the API server and
+// the runner worker.
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// User represents a registered account. Passwords are never stored in
+// plaintext; PasswordHash is a bcrypt hash.
+type User struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Email        string    `gorm:"uniqueIndex" json:"email"`
+	PasswordHash string    `json:"-"`
+	Role         string    `json:"role"` // "user" or "admin"
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Problem represents a coding problem.
+type Problem struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	Title        string         `json:"title"`
+	Question     string         `json:"question"`
+	Level        string         `json:"level"`
+	Tags         datatypes.JSON `json:"tags"`         // stored as a JSON array of strings
+	CodeStubs    datatypes.JSON `json:"codeStubs"`    // stored as a JSON object: language -> stub
+	TestCases    []TestCase     `json:"testCases"`    // one-to-many relation
+	FunctionName string         `json:"functionName"` // the name of the function to call
+}
+
+// TestCase represents a sample test case for a problem. Input/Output hold
+// the payload inline unless it exceeds storage.InlineThresholdBytes, in
+// which case it's offloaded to object storage and InputKey/OutputKey
+// point at the object-store path instead.
+type TestCase struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	ProblemID uint           `json:"problemId"`
+	Type      string         `json:"type"`
+	Input     datatypes.JSON `json:"input"`
+	Output    datatypes.JSON `json:"output"`
+	InputKey  string         `json:"inputKey,omitempty"`
+	OutputKey string         `json:"outputKey,omitempty"`
+}
+
+// ProblemVersion is an immutable snapshot of a problem's judging data:
+// its test cases, the function to call, and resource limits. Submissions
+// are judged against a specific version rather than the live Problem
+// record, so fixing a buggy test case never invalidates past history.
+type ProblemVersion struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	ProblemID    uint           `json:"problemId"`
+	Version      int            `json:"version"`
+	IsEnabled    bool           `json:"isEnabled"`
+	TestCases    datatypes.JSON `json:"testCases"` // []VersionedTestCase snapshot
+	FunctionName string         `json:"functionName"`
+	TimeLimit    int            `json:"timeLimit"`  // milliseconds
+	MemoryLimit  int            `json:"memoryLimit"` // kilobytes
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// VersionedTestCase is one entry of ProblemVersion.TestCases. Like
+// TestCase, large inputs/outputs are offloaded to object storage and
+// referenced by InputKey/OutputKey instead of stored inline.
+type VersionedTestCase struct {
+	Input     datatypes.JSON `json:"input"`
+	Output    datatypes.JSON `json:"output"`
+	InputKey  string         `json:"inputKey,omitempty"`
+	OutputKey string         `json:"outputKey,omitempty"`
+}
+
+// Submission represents a user submission.
+type Submission struct {
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	ProblemID        uint           `json:"problemId"`
+	ProblemVersionID uint           `json:"problemVersionId"`
+	UserID           string         `json:"userId"`
+	Code             string         `json:"code"`
+	Language         string         `json:"language"`
+	CompetitionID    string         `json:"competitionId"`
+	Passed           bool           `json:"passed"`
+	Verdict          string         `json:"verdict"` // overall verdict: AC, WA, TLE, MLE, RE or CE
+	Output           string         `json:"output"`
+	Results          datatypes.JSON `json:"results"` // []TestCaseResult, one entry per testcase
+	Status           string         `json:"status"`  // "pending" or "completed"
+	CreatedAt        time.Time      `json:"createdAt"`
+}
+
+// TestCaseResult is the per-testcase outcome stored in Submission.Results.
+type TestCaseResult struct {
+	Index    int    `json:"index"` // position within ProblemVersion.TestCases
+	Verdict  string `json:"verdict"`
+	Output   string `json:"output"`
+	TimeMs   int64  `json:"timeMs"`
+	MemoryKB int64  `json:"memoryKb"`
+}
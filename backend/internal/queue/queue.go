@@ -0,0 +1,80 @@
+// Package queue wraps the submission job queue shared by the API server
+// (producer) and the runner workers (consumers). It is backed by Asynq,
+// which in turn uses Redis as its broker.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeSubmissionExecute identifies the task type that asks a runner to
+// execute a submission against a problem's test cases.
+const TypeSubmissionExecute = "submission:execute"
+
+// SubmissionJob is the payload enqueued by the API server for every
+// submission that needs to be judged.
+type SubmissionJob struct {
+	SubmissionID     uint   `json:"submissionId"`
+	ProblemID        uint   `json:"problemId"`
+	ProblemVersionID uint   `json:"problemVersionId"`
+	Language         string `json:"language"`
+	Code             string `json:"code"`
+}
+
+// NewClient returns an Asynq client the API server can use to enqueue
+// submission jobs. redisAddr is a host:port pair, e.g. "localhost:6379".
+func NewClient(redisAddr string) *asynq.Client {
+	return asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddr})
+}
+
+// EnqueueSubmission serializes a SubmissionJob and pushes it onto the
+// queue for the runners to pick up.
+func EnqueueSubmission(client *asynq.Client, job SubmissionJob) (*asynq.TaskInfo, error) {
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	task := asynq.NewTask(TypeSubmissionExecute, payload)
+	return client.Enqueue(task, asynq.MaxRetry(2), asynq.Timeout(2*time.Minute))
+}
+
+// ParseSubmissionJob decodes the payload of a submission task.
+func ParseSubmissionJob(task *asynq.Task) (SubmissionJob, error) {
+	var job SubmissionJob
+	err := json.Unmarshal(task.Payload(), &job)
+	return job, err
+}
+
+// NewServer returns an Asynq server the runner uses to consume submission
+// jobs. concurrency controls how many jobs a single runner process will
+// execute at once.
+func NewServer(redisAddr string, concurrency int) *asynq.Server {
+	return asynq.NewServer(
+		asynq.RedisClientOpt{Addr: redisAddr},
+		asynq.Config{Concurrency: concurrency},
+	)
+}
+
+// Handler is implemented by anything that can execute a submission job.
+// It lives here (rather than in the runner binary) so the server package
+// never needs to import Docker-specific execution code.
+type Handler interface {
+	HandleSubmission(ctx context.Context, job SubmissionJob) error
+}
+
+// Mux builds the asynq.ServeMux that routes submission tasks to h.
+func Mux(h Handler) *asynq.ServeMux {
+	mux := asynq.NewServeMux()
+	mux.HandleFunc(TypeSubmissionExecute, func(ctx context.Context, t *asynq.Task) error {
+		job, err := ParseSubmissionJob(t)
+		if err != nil {
+			return err
+		}
+		return h.HandleSubmission(ctx, job)
+	})
+	return mux
+}
@@ -0,0 +1,76 @@
+// Package storage wraps the MinIO/S3 object store used for test-case
+// inputs/outputs too big to cram into Postgres datatypes.JSON columns and
+// for bulk test-case archives uploaded via presigned URLs. Submitted code
+// and container stdout/stderr are not written here; they stay on the
+// Submission row.
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// InlineThresholdBytes is the size above which a test case's input or
+// output is offloaded to object storage instead of being stored inline
+// as datatypes.JSON. TestCase.InputKey/OutputKey are set instead.
+const InlineThresholdBytes = 1 << 20 // 1MB
+
+// Store is a thin wrapper around a MinIO client scoped to a single
+// bucket.
+type Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// New connects to a MinIO/S3-compatible endpoint and ensures the target
+// bucket exists.
+func New(ctx context.Context, endpoint, accessKey, secretKey, bucket string, useSSL bool) (*Store, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Store{client: client, bucket: bucket}, nil
+}
+
+// Put uploads data under key, replacing any existing object there.
+func (s *Store) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, data, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+// Get streams the object stored under key. Callers must Close the
+// returned reader.
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+}
+
+// PresignedPutURL returns a URL clients can PUT an object to directly,
+// without routing the (potentially large) payload through the API
+// server.
+func (s *Store) PresignedPutURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := s.client.PresignedPutObject(ctx, s.bucket, key, expiry)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}